@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+//TestCopierSameNameDifferentContent verifies that two source files sharing a basename but not
+//content (e.g. a/photo.jpg and b/photo.jpg) are both kept in the output tree under
+//-layout=extension, instead of the second being dropped as a false duplicate of the first
+func TestCopierSameNameDifferentContent(t *testing.T) {
+	dir := t.TempDir()
+
+	srcA := filepath.Join(dir, "a.jpg")
+	srcB := filepath.Join(dir, "b.jpg")
+	if err := os.WriteFile(srcA, []byte("content a"), 0644); err != nil {
+		t.Fatalf("could not write %s: %v", srcA, err)
+	}
+	if err := os.WriteFile(srcB, []byte("content b"), 0644); err != nil {
+		t.Fatalf("could not write %s: %v", srcB, err)
+	}
+
+	out := filepath.Join(dir, "out")
+
+	files := []*File{
+		{FullPath: srcA, Name: "photo.jpg", Extension: ".jpg", Hash: "hashA", Size: 9},
+		{FullPath: srcB, Name: "photo.jpg", Extension: ".jpg", Hash: "hashB", Size: 9},
+	}
+
+	in := make(chan *File, len(files))
+	for _, f := range files {
+		in <- f
+	}
+	close(in)
+
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	Copier(wg, in, LocalStorage{}, out, LayoutExtension, ModeCopy, hashAlgorithms["md5"], nil, NewReporter(ReportNone))
+	wg.Wait()
+
+	entries, err := os.ReadDir(filepath.Join(out, "jpg"))
+	if err != nil {
+		t.Fatalf("could not read output directory: %v", err)
+	}
+
+	names := map[string]struct{}{}
+	for _, e := range entries {
+		names[e.Name()] = struct{}{}
+	}
+
+	if _, ok := names["photo.jpg"]; !ok {
+		t.Errorf("expected photo.jpg in output, got %v", names)
+	}
+	if len(names) != 2 {
+		t.Errorf("expected both files to be kept (2 entries), got %v", names)
+	}
+}
+
+//TestCopierResumePreservesManifest verifies that a -resume run, which skips files Walker judges
+//unchanged and so never sends them to Copier, doesn't erase their ManifestEntry when it saves
+//root/manifest.json with only the files this invocation actually saw
+func TestCopierResumePreservesManifest(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+
+	src := filepath.Join(dir, "a.jpg")
+	if err := os.WriteFile(src, []byte("content a"), 0644); err != nil {
+		t.Fatalf("could not write %s: %v", src, err)
+	}
+
+	f := &File{FullPath: src, Name: "photo.jpg", Extension: ".jpg", Hash: "hashA", Size: 9}
+
+	in := make(chan *File, 1)
+	in <- f
+	close(in)
+
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	Copier(wg, in, LocalStorage{}, out, LayoutExtension, ModeCopy, hashAlgorithms["md5"], nil, NewReporter(ReportNone))
+	wg.Wait()
+
+	resume, err := LoadManifest(LocalStorage{}, out)
+	if err != nil {
+		t.Fatalf("could not load manifest: %v", err)
+	}
+	if len(resume) != 1 {
+		t.Fatalf("expected 1 manifest entry after first run, got %d", len(resume))
+	}
+
+	//simulate a -resume run that finds nothing new: Walker skips the unchanged file entirely,
+	//so Copier never receives it on in
+	in2 := make(chan *File)
+	close(in2)
+
+	wg.Add(1)
+	Copier(wg, in2, LocalStorage{}, out, LayoutExtension, ModeCopy, hashAlgorithms["md5"], resume, NewReporter(ReportNone))
+	wg.Wait()
+
+	after, err := LoadManifest(LocalStorage{}, out)
+	if err != nil {
+		t.Fatalf("could not load manifest after resume run: %v", err)
+	}
+	if len(after) != 1 {
+		t.Errorf("expected resume run to preserve the 1 existing manifest entry, got %d", len(after))
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+//reflink clones dest from src via the FICLONE ioctl, returning an error if the underlying
+//filesystem does not support reflinks
+func reflink(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("Could not open source file %s: %v", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("Could not create destination file %s: %v", dest, err)
+	}
+	defer out.Close()
+
+	if err := unix.IoctlFileClone(int(out.Fd()), int(in.Fd())); err != nil {
+		os.Remove(dest)
+		return fmt.Errorf("FICLONE not supported from %s to %s: %v", src, dest, err)
+	}
+
+	return nil
+}
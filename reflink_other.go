@@ -0,0 +1,10 @@
+//go:build !linux
+
+package main
+
+import "fmt"
+
+//reflink is unsupported outside Linux; Transfer falls back to a regular copy
+func reflink(src, dest string) error {
+	return fmt.Errorf("reflink is only supported on Linux")
+}